@@ -1,14 +1,33 @@
 package main
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"embed"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"html/template"
+	"image"
+	"image/draw"
 	"io"
 	"io/fs"
 	"log"
+	"math"
+	"math/bits"
+	"mime/multipart"
+	"net"
 	"net/http"
+	"net/textproto"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -17,24 +36,150 @@ import (
 	"sync"
 	"time"
 
+	"github.com/andybalholm/brotli"
+	"github.com/dustin/go-humanize"
 	"github.com/fsnotify/fsnotify"
+	"github.com/klauspost/compress/zstd"
 	"github.com/ncruces/zenity"
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+	"golang.org/x/crypto/acme/autocert"
 )
 
+//go:embed templates/listing.html.tmpl
+var defaultListingTemplateFS embed.FS
+
+const defaultListingTemplateName = "templates/listing.html.tmpl"
+
 type Config struct {
-	Port      string `json:"port"`
-	IconDir   string `json:"iconDir"`
-	DebugMode bool   `json:"debugMode"`
+	Port            string            `json:"port"`
+	IconDir         string            `json:"iconDir"`
+	DebugMode       bool              `json:"debugMode"`
+	ListingTemplate string            `json:"listingTemplate,omitempty"` // path to an override for the default embedded listing template
+	Compression     CompressionConfig `json:"compression"`
+	TLS             TLSConfig         `json:"tls"`
+	SigningKeyPath  string            `json:"signingKeyPath,omitempty"` // PEM-encoded PKCS8 Ed25519 key used to sign /Icons/manifest.json
+}
+
+// CompressionConfig selects which content encodings IconCache precomputes
+// for icons and the listing page, trading RAM at rebuild time for smaller
+// responses. Level is one of "fastest", "default", or "best"; anything
+// else is treated as "default".
+type CompressionConfig struct {
+	Gzip   bool   `json:"gzip"`
+	Brotli bool   `json:"brotli"`
+	Zstd   bool   `json:"zstd"`
+	Level  string `json:"level"`
+}
+
+// defaultCompressionConfig is applied to brand-new configs and to configs
+// written before this setting existed.
+func defaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{Gzip: true, Level: "default"}
+}
+
+// TLSConfig selects how the server terminates HTTPS. Mode is one of:
+//   - "off" (default): plain HTTP, current behavior
+//   - "file": TLS using an explicit certificate/key pair
+//   - "acme": automatic certificate management via Let's Encrypt (autocert's
+//     default CA; no other CA can be configured), restricted to HostWhitelist
+type TLSConfig struct {
+	Mode          string   `json:"mode"`
+	CertFile      string   `json:"certFile,omitempty"`
+	KeyFile       string   `json:"keyFile,omitempty"`
+	HostWhitelist []string `json:"hostWhitelist,omitempty"`
+	CacheDir      string   `json:"cacheDir,omitempty"`
+}
+
+// defaultTLSConfig is what promptTLSConfig returns when the user picks
+// plaintext or cancels the prompt: keep serving plain HTTP.
+func defaultTLSConfig() TLSConfig {
+	return TLSConfig{Mode: "off"}
+}
+
+// Listing is the per-icon metadata shown on the "/Icons/" browsable
+// listing page, and returned as JSON when the client asks for it.
+type Listing struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	HumanSize string    `json:"humanSize"`
+	ModTime   time.Time `json:"modTime"`
+}
+
+// ListingPage is the data handed to the listing template, and the shape
+// of the JSON response for `Accept: application/json` requests against
+// "/Icons/".
+type ListingPage struct {
+	Icons []Listing `json:"icons"`
+	Total int       `json:"total"`
+	Page  int       `json:"page"`
+	Limit int       `json:"limit"`
+	Sort  string    `json:"sort"`
+	Order string    `json:"order"`
+}
+
+// compressedVariants holds the precomputed compressed representations of
+// a cached payload; any field is nil if that encoding isn't enabled in
+// Config.Compression.
+type compressedVariants struct {
+	gzip   []byte
+	brotli []byte
+	zstd   []byte
+}
+
+// get returns the bytes for encoding ("gzip", "br", or "zstd") if that
+// variant was precomputed.
+func (cv compressedVariants) get(encoding string) ([]byte, bool) {
+	switch encoding {
+	case "gzip":
+		return cv.gzip, cv.gzip != nil
+	case "br":
+		return cv.brotli, cv.brotli != nil
+	case "zstd":
+		return cv.zstd, cv.zstd != nil
+	}
+	return nil, false
+}
+
+type iconEntry struct {
+	content []byte
+	encoded compressedVariants
+	etag    string    // quoted strong ETag, e.g. `"a1b2c3d4e5f6a7b8"`
+	sha256  string    // full hex SHA-256 of content, for the manifest and /Icons/by-hash
+	modTime time.Time // file mtime captured during Rebuild
+}
+
+// ManifestEntry describes one icon in /Icons/manifest.json.
+type ManifestEntry struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest is the content-addressed listing served at
+// /Icons/manifest.json. Revision increases by one on every cache Rebuild,
+// so consumers can cheaply detect that the icon set changed.
+type Manifest struct {
+	Revision  int             `json:"revision"`
+	Icons     []ManifestEntry `json:"icons"`
+	Signature string          `json:"signature,omitempty"` // base64 Ed25519 signature, present when Config.SigningKeyPath is set
 }
 
 type IconCache struct {
 	mu          sync.RWMutex
-	icons       map[string][]byte // filename -> content
-	names       []string          // sorted list of filenames
-	nameIndex   map[string]int    // filename -> index in names slice
-	searchIndex map[string][]int  // search term -> slice of indexes in names
-	htmlPage    []byte            // cached HTML page
-	gzippedPage []byte            // gzipped version of HTML page
+	icons       map[string]iconEntry // filename -> content + cache metadata
+	names       []string             // sorted list of filenames
+	nameIndex   map[string]int       // filename -> index in names slice
+	searchIndex map[string][]int     // search term -> slice of indexes in names
+	htmlPage    []byte               // cached HTML page
+	htmlEncoded compressedVariants   // precomputed encodings of htmlPage
+	htmlETag    string               // quoted strong ETag for htmlPage
+	phash       map[string]uint64    // filename -> 64-bit perceptual hash
+	hashIndex   map[string]string    // full hex SHA-256 -> filename, for /Icons/by-hash
+	revision    int                  // increments on every Rebuild, surfaced in the manifest
+	listingTmpl *template.Template   // renders the browsable listing page; fixed for the cache's lifetime
+	compression CompressionConfig    // which encodings to precompute; fixed for the cache's lifetime
+	signingKey  ed25519.PrivateKey   // optional; signs the manifest when Config.SigningKeyPath is set
 	lastUpdated time.Time
 }
 
@@ -341,6 +486,55 @@ func promptDebugMode() (bool, error) {
 	return true, nil
 }
 
+// promptTLSConfig mirrors the interactive port/directory prompt pattern
+// above: ask the user, on first run, how this server should terminate
+// HTTPS, then gather whatever that mode needs.
+func promptTLSConfig() (TLSConfig, error) {
+	choice, err := zenity.List(
+		"Select how this server should handle HTTPS:",
+		[]string{"Plaintext (no TLS)", "Explicit certificate and key files", "Automatic certificate via ACME"},
+		zenity.Title("TLS Mode"),
+	)
+	if err == zenity.ErrCanceled {
+		return defaultTLSConfig(), nil
+	}
+	if err != nil {
+		return TLSConfig{}, err
+	}
+
+	switch choice {
+	case "Explicit certificate and key files":
+		certFile, err := zenity.SelectFile(zenity.Title("Select TLS certificate file"))
+		if err != nil {
+			return TLSConfig{}, err
+		}
+		keyFile, err := zenity.SelectFile(zenity.Title("Select TLS private key file"))
+		if err != nil {
+			return TLSConfig{}, err
+		}
+		return TLSConfig{Mode: "file", CertFile: certFile, KeyFile: keyFile}, nil
+
+	case "Automatic certificate via ACME":
+		hosts, err := zenity.Entry(
+			"Enter the comma-separated hostnames this server will be reachable at:",
+			zenity.Title("ACME Hostnames"),
+		)
+		if err != nil {
+			return TLSConfig{}, err
+		}
+		var whitelist []string
+		for _, h := range strings.Split(hosts, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				whitelist = append(whitelist, h)
+			}
+		}
+		return TLSConfig{Mode: "acme", HostWhitelist: whitelist, CacheDir: "acme-cache"}, nil
+
+	default:
+		return defaultTLSConfig(), nil
+	}
+}
+
 func loadOrCreateConfig() (*Config, error) {
 	log.Printf("%sChecking config file: %s%s", colorGray, configFile, colorReset)
 
@@ -352,10 +546,17 @@ func loadOrCreateConfig() (*Config, error) {
 			return nil, fmt.Errorf("failed to get debug mode preference: %v", err)
 		}
 
+		tlsConfig, err := promptTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS preference: %v", err)
+		}
+
 		cfg := &Config{
-			IconDir:   "nil",
-			Port:      "nil",
-			DebugMode: debugModeEnabled,
+			IconDir:     "nil",
+			Port:        "nil",
+			DebugMode:   debugModeEnabled,
+			Compression: defaultCompressionConfig(),
+			TLS:         tlsConfig,
 		}
 		log.Printf("%sCreating default config with DebugMode=%v%s", colorYellow, cfg.DebugMode, colorReset)
 		if err := saveConfig(cfg); err != nil {
@@ -392,6 +593,27 @@ func loadOrCreateConfig() (*Config, error) {
 				return nil, fmt.Errorf("failed to update config with DebugMode: %v", err)
 			}
 		}
+		// Validate the Compression section
+		if _, exists := tempMap["compression"]; !exists {
+			log.Printf("%sCompression config missing from config. Defaulting to gzip.%s", colorYellow, colorReset)
+			cfg.Compression = defaultCompressionConfig()
+			if err := saveConfig(&cfg); err != nil {
+				return nil, fmt.Errorf("failed to update config with Compression: %v", err)
+			}
+		}
+
+		// Validate the TLS section
+		if _, exists := tempMap["tls"]; !exists {
+			log.Printf("%sTLS config missing from config. Prompting user...%s", colorYellow, colorReset)
+			tlsConfig, err := promptTLSConfig()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get TLS preference: %v", err)
+			}
+			cfg.TLS = tlsConfig
+			if err := saveConfig(&cfg); err != nil {
+				return nil, fmt.Errorf("failed to update config with TLS: %v", err)
+			}
+		}
 	} else {
 		log.Printf("%sFailed to unmarshal config into map for DebugMode validation%s", colorRed, colorReset)
 	}
@@ -406,36 +628,138 @@ func saveConfig(cfg *Config) error {
 	return os.WriteFile(configFile, data, 0644)
 }
 
-func NewIconCache(iconDir string) (*IconCache, error) {
+// phashRasterSize is the side length (in pixels) icons are rasterized to
+// before computing a perceptual hash. 32x32 is plenty of detail for an
+// 8x8 DCT while keeping rendering cheap.
+const phashRasterSize = 32
+
+// defaultDuplicateThreshold is the Hamming distance, in bits, at or below
+// which two icons' perceptual hashes are considered near-duplicates.
+const defaultDuplicateThreshold = 5
+
+// listingTemplateFuncs are the helper functions available to the listing
+// template, beyond the Go html/template builtins.
+var listingTemplateFuncs = template.FuncMap{
+	"humanSize": humanize.Bytes,
+}
+
+// loadListingTemplate parses cfg.ListingTemplate if set, so operators can
+// customize the browsable listing's look without recompiling, falling
+// back to the template embedded at build time.
+func loadListingTemplate(cfg *Config) (*template.Template, error) {
+	if cfg.ListingTemplate != "" {
+		tmpl, err := template.New(filepath.Base(cfg.ListingTemplate)).Funcs(listingTemplateFuncs).ParseFiles(cfg.ListingTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("parse listing template %s: %w", cfg.ListingTemplate, err)
+		}
+		return tmpl, nil
+	}
+
+	data, err := defaultListingTemplateFS.ReadFile(defaultListingTemplateName)
+	if err != nil {
+		return nil, fmt.Errorf("read embedded listing template: %w", err)
+	}
+	tmpl, err := template.New("listing").Funcs(listingTemplateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse embedded listing template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func NewIconCache(cfg *Config) (*IconCache, error) {
+	tmpl, err := loadListingTemplate(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var signingKey ed25519.PrivateKey
+	if cfg.SigningKeyPath != "" {
+		signingKey, err = loadSigningKey(cfg.SigningKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("load signing key: %w", err)
+		}
+	}
+
 	cache := &IconCache{
-		icons:       make(map[string][]byte),
+		icons:       make(map[string]iconEntry),
 		nameIndex:   make(map[string]int),
 		searchIndex: make(map[string][]int),
+		phash:       make(map[string]uint64),
+		hashIndex:   make(map[string]string),
+		listingTmpl: tmpl,
+		compression: cfg.Compression,
+		signingKey:  signingKey,
 	}
-	if err := cache.Rebuild(iconDir); err != nil {
+	if err := cache.Rebuild(cfg.IconDir); err != nil {
 		return nil, err
 	}
 	return cache, nil
 }
 
+// loadSigningKey reads a PEM-encoded PKCS8 Ed25519 private key from path,
+// used to sign /Icons/manifest.json.
+func loadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse PKCS8 key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain an Ed25519 private key", path)
+	}
+	return edKey, nil
+}
+
 func (c *IconCache) Rebuild(iconDir string) error {
 	files, err := getSortedIconNames(iconDir)
 	if err != nil {
 		return err
 	}
 
-	newIcons := make(map[string][]byte)
+	newIcons := make(map[string]iconEntry)
 	newNameIndex := make(map[string]int)
 	newSearchIndex := make(map[string][]int)
+	newPHash := make(map[string]uint64)
+	newHashIndex := make(map[string]string)
 
 	for i, file := range files {
-		content, err := os.ReadFile(filepath.Join(iconDir, file))
+		path := filepath.Join(iconDir, file)
+		content, err := os.ReadFile(path)
 		if err != nil {
 			return err
 		}
 
-		newIcons[file] = content
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(content)
+		sha256Hex := hex.EncodeToString(sum[:])
+
+		newIcons[file] = iconEntry{
+			content: content,
+			encoded: compressAll(content, c.compression, file),
+			etag:    computeETag(content),
+			sha256:  sha256Hex,
+			modTime: info.ModTime(),
+		}
 		newNameIndex[file] = i
+		newHashIndex[sha256Hex] = file
+
+		if gray, err := rasterizeSVG(content, phashRasterSize); err != nil {
+			log.Printf("%sSkipping perceptual hash for %s: %v%s", colorYellow, file, err, colorReset)
+		} else {
+			newPHash[file] = computePHash(gray)
+		}
 
 		// Build search index (filename without .svg)
 		name := strings.TrimSuffix(strings.ToLower(file), ".svg")
@@ -446,29 +770,17 @@ func (c *IconCache) Rebuild(iconDir string) error {
 		}
 	}
 
-	// Build HTML page
-	var htmlBuilder strings.Builder
-	htmlBuilder.WriteString(`<!DOCTYPE html><html><head><title>Icon Server - All Icons</title><style>body { font-family: Arial, sans-serif; margin: 20px; }.icon-grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(250px, 1fr)); gap: 15px; }.icon-item { text-align: center; padding: 10px; border: 1px solid #eee; border-radius: 5px; }.icon-item:hover { background-color: #f5f5f5; }.icon-img { height: 50px; width: 50px; margin-bottom: 5px; }.icon-name { word-break: break-all; font-size: 12px; }</style></head><body><h1>Available Icons (Total: ` + insertCommas(len(files)) + `)</h1><div class="icon-grid">`)
-
-	for _, name := range files {
-		htmlBuilder.WriteString(`<div class="icon-item"><a href="/Icons/`)
-		htmlBuilder.WriteString(name)
-		htmlBuilder.WriteString(`"><div class="icon-name">`)
-		htmlBuilder.WriteString(name)
-		htmlBuilder.WriteString(`</div></a></div>`)
+	// Build the default (unsorted-params, unpaginated) rendering of the
+	// listing page, which is what gets cached with an ETag for fast,
+	// conditional-request-aware serving of "/Icons/".
+	entries := sortedListingEntries(newIcons, files, "name", "asc")
+	htmlPage, err := renderListingPage(c.listingTmpl, entries, len(files), 1, 0, "name", "asc")
+	if err != nil {
+		return fmt.Errorf("render listing page: %w", err)
 	}
 
-	htmlBuilder.WriteString(`</div></body></html>`)
+	htmlEncoded := compressAll(htmlPage, c.compression, "listing page")
 
-	htmlPage := []byte(htmlBuilder.String())
-	var gzippedBuf bytes.Buffer
-	gz := gzip.NewWriter(&gzippedBuf)
-	if _, err := gz.Write(htmlPage); err != nil {
-		return err
-	}
-	if err := gz.Close(); err != nil {
-		return err
-	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -477,17 +789,396 @@ func (c *IconCache) Rebuild(iconDir string) error {
 	c.nameIndex = newNameIndex
 	c.searchIndex = newSearchIndex
 	c.htmlPage = htmlPage
-	c.gzippedPage = gzippedBuf.Bytes()
+	c.htmlEncoded = htmlEncoded
+	c.htmlETag = computeETag(htmlPage)
+	c.phash = newPHash
+	c.hashIndex = newHashIndex
+	c.revision++
 	c.lastUpdated = time.Now()
 
 	return nil
 }
 
-func (c *IconCache) GetIcon(name string) ([]byte, bool) {
+// rasterizeSVG renders an SVG document to a size x size grayscale raster,
+// for use as the input to a perceptual hash.
+func rasterizeSVG(data []byte, size int) (*image.Gray, error) {
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse svg: %w", err)
+	}
+	icon.SetTarget(0, 0, float64(size), float64(size))
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	scanner := rasterx.NewScannerGV(size, size, img, img.Bounds())
+	raster := rasterx.NewDasher(size, size, scanner)
+	icon.Draw(raster, 1.0)
+
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, image.Point{}, draw.Src)
+	return gray, nil
+}
+
+// computePHash derives a 64-bit perceptual hash from img using the
+// standard pHash recipe: an 8x8 2D DCT of the raster, keeping the
+// low-frequency coefficients (skipping the DC term) and setting a bit
+// wherever a coefficient exceeds the median of the others.
+func computePHash(img *image.Gray) uint64 {
+	const n = 8
+	size := img.Bounds().Dx()
+
+	pixels := make([][]float64, size)
+	for y := 0; y < size; y++ {
+		pixels[y] = make([]float64, size)
+		for x := 0; x < size; x++ {
+			pixels[y][x] = float64(img.GrayAt(x, y).Y)
+		}
+	}
+
+	dct := dct2D(pixels, n)
+
+	coeffs := make([]float64, 0, n*n-1)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if x == 0 && y == 0 {
+				continue // DC term only reflects overall brightness
+			}
+			coeffs = append(coeffs, dct[y][x])
+		}
+	}
+	median := medianOf(coeffs)
+
+	var hash uint64
+	var bit uint
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if x == 0 && y == 0 {
+				continue
+			}
+			if dct[y][x] > median {
+				hash |= 1 << bit
+			}
+			bit++
+		}
+	}
+	return hash
+}
+
+// dct2D computes the outSize x outSize 2D discrete cosine transform
+// (DCT-II) of an NxN input, i.e. just the low-frequency coefficients
+// needed for a perceptual hash.
+func dct2D(pixels [][]float64, outSize int) [][]float64 {
+	size := len(pixels)
+	result := make([][]float64, outSize)
+	for u := 0; u < outSize; u++ {
+		result[u] = make([]float64, outSize)
+		for v := 0; v < outSize; v++ {
+			var sum float64
+			for x := 0; x < size; x++ {
+				for y := 0; y < size; y++ {
+					sum += pixels[x][y] *
+						math.Cos(float64(2*x+1)*float64(u)*math.Pi/float64(2*size)) *
+						math.Cos(float64(2*y+1)*float64(v)*math.Pi/float64(2*size))
+				}
+			}
+			alphaU, alphaV := 1.0, 1.0
+			if u == 0 {
+				alphaU = 1.0 / math.Sqrt2
+			}
+			if v == 0 {
+				alphaV = 1.0 / math.Sqrt2
+			}
+			result[u][v] = 0.25 * alphaU * alphaV * sum
+		}
+	}
+	return result
+}
+
+// medianOf returns the median of values, which is left unmodified.
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// hammingDistance returns the number of differing bits between a and b.
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// unionFind is a disjoint-set structure used to group icons whose
+// perceptual hashes are within the duplicate threshold of one another.
+type unionFind struct {
+	parent []int
+	rank   []int
+}
+
+func newUnionFind(n int) *unionFind {
+	uf := &unionFind{parent: make([]int, n), rank: make([]int, n)}
+	for i := range uf.parent {
+		uf.parent[i] = i
+	}
+	return uf
+}
+
+func (uf *unionFind) find(x int) int {
+	for uf.parent[x] != x {
+		uf.parent[x] = uf.parent[uf.parent[x]]
+		x = uf.parent[x]
+	}
+	return x
+}
+
+func (uf *unionFind) union(a, b int) {
+	ra, rb := uf.find(a), uf.find(b)
+	if ra == rb {
+		return
+	}
+	if uf.rank[ra] < uf.rank[rb] {
+		ra, rb = rb, ra
+	}
+	uf.parent[rb] = ra
+	if uf.rank[ra] == uf.rank[rb] {
+		uf.rank[ra]++
+	}
+}
+
+// FindDuplicateGroups groups cached icons whose perceptual hashes are
+// within threshold Hamming distance of each other. This is an O(n^2) scan
+// over every pair of icons — fine at the icon counts this server targets,
+// but worth knowing before pointing it at a five-figure library.
+func (c *IconCache) FindDuplicateGroups(threshold int) [][]string {
+	c.mu.RLock()
+	var names []string
+	var hashes []uint64
+	for _, name := range c.names {
+		hash, exists := c.phash[name]
+		if !exists {
+			// Icon failed to rasterize during Rebuild; it has no
+			// perceptual hash, so it can't be compared for duplicates.
+			continue
+		}
+		names = append(names, name)
+		hashes = append(hashes, hash)
+	}
+	c.mu.RUnlock()
+
+	uf := newUnionFind(len(names))
+	for i := 0; i < len(names); i++ {
+		for j := i + 1; j < len(names); j++ {
+			if hammingDistance(hashes[i], hashes[j]) <= threshold {
+				uf.union(i, j)
+			}
+		}
+	}
+
+	groups := make(map[int][]string)
+	for i, name := range names {
+		root := uf.find(i)
+		groups[root] = append(groups[root], name)
+	}
+
+	var result [][]string
+	for _, group := range groups {
+		if len(group) > 1 {
+			sort.Strings(group)
+			result = append(result, group)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i][0] < result[j][0] })
+	return result
+}
+
+// SimilarTo returns the names of icons whose perceptual hash is within
+// threshold Hamming distance of name's, excluding name itself. The second
+// return value is false if name has no perceptual hash: either it isn't a
+// known icon, or it failed to rasterize during Rebuild.
+func (c *IconCache) SimilarTo(name string, threshold int) ([]string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	target, exists := c.phash[name]
+	if !exists {
+		return nil, false
+	}
+
+	var results []string
+	for _, other := range c.names {
+		if other == name {
+			continue
+		}
+		otherHash, exists := c.phash[other]
+		if !exists {
+			continue
+		}
+		if hammingDistance(target, otherHash) <= threshold {
+			results = append(results, other)
+		}
+	}
+	return results, true
+}
+
+// computeETag derives a strong, quoted ETag (RFC 7232) from the SHA-256 of
+// data, truncated to 8 bytes; that's plenty of collision resistance for
+// cache-validation purposes while keeping the header short.
+func computeETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`"%x"`, sum[:8])
+}
+
+func gzipLevel(level string) int {
+	switch level {
+	case "fastest":
+		return gzip.BestSpeed
+	case "best":
+		return gzip.BestCompression
+	default:
+		return gzip.DefaultCompression
+	}
+}
+
+func brotliLevel(level string) int {
+	switch level {
+	case "fastest":
+		return 1
+	case "best":
+		return 11
+	default:
+		return 6
+	}
+}
+
+func zstdLevel(level string) zstd.EncoderLevel {
+	switch level {
+	case "fastest":
+		return zstd.SpeedFastest
+	case "best":
+		return zstd.SpeedBestCompression
+	default:
+		return zstd.SpeedDefault
+	}
+}
+
+func compressGzip(data []byte, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func compressBrotli(data []byte, level int) []byte {
+	var buf bytes.Buffer
+	bw := brotli.NewWriterLevel(&buf, level)
+	bw.Write(data)
+	bw.Close()
+	return buf.Bytes()
+}
+
+func compressZstd(data []byte, level zstd.EncoderLevel) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf, zstd.WithEncoderLevel(level))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// compressAll precomputes every encoding enabled in compression for data.
+// Encodings that fail to compress are simply omitted rather than failing
+// the whole rebuild.
+func compressAll(data []byte, compression CompressionConfig, label string) compressedVariants {
+	var variants compressedVariants
+	if compression.Gzip {
+		if gz, err := compressGzip(data, gzipLevel(compression.Level)); err != nil {
+			log.Printf("%sGzip compression failed for %s: %v%s", colorYellow, label, err, colorReset)
+		} else {
+			variants.gzip = gz
+		}
+	}
+	if compression.Brotli {
+		variants.brotli = compressBrotli(data, brotliLevel(compression.Level))
+	}
+	if compression.Zstd {
+		if zs, err := compressZstd(data, zstdLevel(compression.Level)); err != nil {
+			log.Printf("%sZstd compression failed for %s: %v%s", colorYellow, label, err, colorReset)
+		} else {
+			variants.zstd = zs
+		}
+	}
+	return variants
+}
+
+func (c *IconCache) GetIcon(name string) (iconEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, exists := c.icons[name]
+	return entry, exists
+}
+
+// GetByHash looks up an icon by its full hex SHA-256 content hash, for
+// /Icons/by-hash/{sha256}.
+func (c *IconCache) GetByHash(hash string) (iconEntry, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	content, exists := c.icons[name]
-	return content, exists
+	name, exists := c.hashIndex[hash]
+	if !exists {
+		return iconEntry{}, false
+	}
+	entry, exists := c.icons[name]
+	return entry, exists
+}
+
+// Manifest builds a snapshot of the current icon set for /Icons/manifest.json,
+// signing it with the configured Ed25519 key if any.
+func (c *IconCache) Manifest() (Manifest, error) {
+	c.mu.RLock()
+	icons := make([]ManifestEntry, 0, len(c.names))
+	for _, name := range c.names {
+		entry := c.icons[name]
+		icons = append(icons, ManifestEntry{
+			Name:   name,
+			Size:   int64(len(entry.content)),
+			SHA256: entry.sha256,
+		})
+	}
+	manifest := Manifest{
+		Revision: c.revision,
+		Icons:    icons,
+	}
+	signingKey := c.signingKey
+	c.mu.RUnlock()
+
+	if signingKey == nil {
+		return manifest, nil
+	}
+
+	unsigned, err := json.Marshal(manifest)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("marshal manifest for signing: %w", err)
+	}
+	sig := ed25519.Sign(signingKey, unsigned)
+	manifest.Signature = base64.StdEncoding.EncodeToString(sig)
+	return manifest, nil
 }
 
 func (c *IconCache) Search(query string) []string {
@@ -515,16 +1206,155 @@ func (c *IconCache) Search(query string) []string {
 	return results
 }
 
+// bundleIcon pairs a filename with its content for archive export.
+type bundleIcon struct {
+	name    string
+	content []byte
+}
+
+// BundleIcons returns the icons matching prefix and search (either may be
+// empty), along with the cache's lastUpdated time and revision, for use as
+// the mtime and ETag basis of a /Icons/bundle.* archive.
+func (c *IconCache) BundleIcons(prefix, search string) ([]bundleIcon, time.Time, int) {
+	var names []string
+	if search != "" {
+		names = c.Search(search)
+	} else {
+		c.mu.RLock()
+		names = append([]string{}, c.names...)
+		c.mu.RUnlock()
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var icons []bundleIcon
+	for _, name := range names {
+		if prefix != "" && !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		icons = append(icons, bundleIcon{name: name, content: c.icons[name].content})
+	}
+	return icons, c.lastUpdated, c.revision
+}
+
 func (c *IconCache) GetHTML() []byte {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.htmlPage
 }
 
-func (c *IconCache) GetGzippedHTML() []byte {
+// GetHTMLEncoded returns the precomputed compressed variants of the
+// cached listing page.
+func (c *IconCache) GetHTMLEncoded() compressedVariants {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.htmlEncoded
+}
+
+// encodingPreference returns the content encodings this cache precomputes,
+// most-compact first, for use in Accept-Encoding negotiation.
+func (c *IconCache) encodingPreference() []string {
+	var prefs []string
+	if c.compression.Brotli {
+		prefs = append(prefs, "br")
+	}
+	if c.compression.Zstd {
+		prefs = append(prefs, "zstd")
+	}
+	if c.compression.Gzip {
+		prefs = append(prefs, "gzip")
+	}
+	return prefs
+}
+
+// GetHTMLMeta returns the ETag and Last-Modified time for the cached
+// listing page, for use in conditional-request handling.
+func (c *IconCache) GetHTMLMeta() (string, time.Time) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.gzippedPage
+	return c.htmlETag, c.lastUpdated
+}
+
+// ListingEntries returns every cached icon's listing metadata, sorted by
+// sortKey ("name", "size", or "modtime") in the given order ("asc" or
+// "desc"; anything else is treated as "asc").
+func (c *IconCache) ListingEntries(sortKey, order string) []Listing {
+	c.mu.RLock()
+	icons := c.icons
+	names := append([]string{}, c.names...)
+	c.mu.RUnlock()
+	return sortedListingEntries(icons, names, sortKey, order)
+}
+
+// RenderListing executes the cache's configured listing template over
+// entries, so callers (the default cached page and dynamic sort/paginate
+// requests alike) share one rendering path.
+func (c *IconCache) RenderListing(entries []Listing, total, page, limit int, sortKey, order string) ([]byte, error) {
+	return renderListingPage(c.listingTmpl, entries, total, page, limit, sortKey, order)
+}
+
+// sortedListingEntries builds the Listing metadata for names from icons
+// and sorts it per sortKey/order.
+func sortedListingEntries(icons map[string]iconEntry, names []string, sortKey, order string) []Listing {
+	entries := make([]Listing, len(names))
+	for i, name := range names {
+		entry := icons[name]
+		entries[i] = Listing{
+			Name:      name,
+			Size:      int64(len(entry.content)),
+			HumanSize: humanize.Bytes(uint64(len(entry.content))),
+			ModTime:   entry.modTime,
+		}
+	}
+
+	less := func(i, j int) bool { return entries[i].Name < entries[j].Name }
+	switch sortKey {
+	case "size":
+		less = func(i, j int) bool { return entries[i].Size < entries[j].Size }
+	case "modtime":
+		less = func(i, j int) bool { return entries[i].ModTime.Before(entries[j].ModTime) }
+	}
+	if order == "desc" {
+		asc := less
+		less = func(i, j int) bool { return asc(j, i) }
+	}
+	sort.Slice(entries, less)
+	return entries
+}
+
+// paginateListing slices entries to the requested page; limit <= 0 means
+// "no pagination, return everything".
+func paginateListing(entries []Listing, page, limit int) []Listing {
+	if limit <= 0 {
+		return entries
+	}
+	start := (page - 1) * limit
+	if start < 0 || start > len(entries) {
+		start = len(entries)
+	}
+	end := start + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[start:end]
+}
+
+// renderListingPage executes tmpl over the given page of listing data.
+func renderListingPage(tmpl *template.Template, entries []Listing, total, page, limit int, sortKey, order string) ([]byte, error) {
+	var buf bytes.Buffer
+	data := ListingPage{
+		Icons: entries,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+		Sort:  sortKey,
+		Order: order,
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func getSortedIconNames(iconDir string) ([]string, error) {
@@ -558,29 +1388,388 @@ func writeJSONResponse(w http.ResponseWriter, files []string, page, limit, total
 	fmt.Fprintf(w, `]}`)
 }
 
+// cacheControlHeader is sent on every cacheable icon/listing response so
+// downstream caches and browsers know it's safe to reuse the body until
+// the next conditional check.
+const cacheControlHeader = "public, max-age=3600"
+
+// checkPreconditions evaluates the RFC 7232 conditional request headers
+// against a resource's current ETag and modification time, in the order
+// the spec mandates: If-Match / If-Unmodified-Since guard writes (relevant
+// once this server grows write endpoints), then If-None-Match / If-
+// Modified-Since guard reads. It returns the status code the caller should
+// short-circuit with, or 0 if the request should proceed normally.
+func checkPreconditions(r *http.Request, etag string, modTime time.Time) int {
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		if !etagMatchesAny(ifMatch, etag) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ifUnmodifiedSince := r.Header.Get("If-Unmodified-Since"); ifUnmodifiedSince != "" {
+		if t, err := http.ParseTime(ifUnmodifiedSince); err == nil && modTime.Truncate(time.Second).After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if etagMatchesAny(ifNoneMatch, etag) {
+			return http.StatusNotModified
+		}
+	} else if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" {
+		if t, err := http.ParseTime(ifModifiedSince); err == nil && !modTime.Truncate(time.Second).After(t) {
+			return http.StatusNotModified
+		}
+	}
+
+	return 0
+}
+
+// etagMatchesAny reports whether header (a comma-separated If-Match /
+// If-None-Match value, possibly "*") matches etag. Weak validators
+// ("W/\"...\"") are compared by their opaque tag per RFC 7232 §2.3.2.
+func etagMatchesAny(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// httpRange is a single byte range resolved against a resource of known
+// size, as requested via the "Range: bytes=..." header (RFC 7233).
+type httpRange struct {
+	start, length int64
+}
+
+func (ra httpRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", ra.start, ra.start+ra.length-1, size)
+}
+
+func (ra httpRange) mimeHeader(contentType string, size int64) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Range": {ra.contentRange(size)},
+		"Content-Type":  {contentType},
+	}
+}
+
+var errInvalidRange = errors.New("invalid range")
+
+// parseRange parses the value of a Range header against a resource of the
+// given size and returns the byte ranges it requests. A nil, nil result
+// means the header didn't resolve to any range and the caller should fall
+// back to serving the full body.
+func parseRange(s string, size int64) ([]httpRange, error) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(s, prefix) {
+		return nil, errInvalidRange
+	}
+
+	var ranges []httpRange
+	unsatisfiable := false
+	for _, part := range strings.Split(s[len(prefix):], ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		start, end, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, errInvalidRange
+		}
+		start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+
+		var ra httpRange
+		if start == "" {
+			// Suffix range "-N": the last N bytes of the resource.
+			if end == "" {
+				return nil, errInvalidRange
+			}
+			n, err := strconv.ParseInt(end, 10, 64)
+			if err != nil || n < 0 {
+				return nil, errInvalidRange
+			}
+			if n == 0 {
+				// Suffix-length 0 is unsatisfiable per RFC 7233 §2.1.
+				unsatisfiable = true
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			ra.start = size - n
+			ra.length = n
+		} else {
+			i, err := strconv.ParseInt(start, 10, 64)
+			if err != nil || i < 0 || i >= size {
+				unsatisfiable = true
+				continue // unsatisfiable range, skip per RFC 7233 §2.1
+			}
+			ra.start = i
+			if end == "" {
+				ra.length = size - ra.start
+			} else {
+				j, err := strconv.ParseInt(end, 10, 64)
+				if err != nil || j < ra.start {
+					return nil, errInvalidRange
+				}
+				if j >= size {
+					j = size - 1
+				}
+				ra.length = j - ra.start + 1
+			}
+		}
+		if ra.start >= ra.start+ra.length {
+			// Resolved to an empty or reversed window; unsatisfiable.
+			unsatisfiable = true
+			continue
+		}
+		ranges = append(ranges, ra)
+	}
+
+	if len(ranges) == 0 && unsatisfiable {
+		return nil, errInvalidRange
+	}
+
+	return ranges, nil
+}
+
+// partialRangeWriter streams the [skip, skip+limit) window of a cached
+// []byte payload, letting range requests against the HTML listing page and
+// against individual icon bodies share the same write path.
+type partialRangeWriter struct {
+	data  []byte
+	skip  int64
+	limit int64
+}
+
+func newPartialRangeWriter(data []byte, ra httpRange) *partialRangeWriter {
+	return &partialRangeWriter{data: data, skip: ra.start, limit: ra.length}
+}
+
+func (p *partialRangeWriter) WriteTo(w io.Writer) (int64, error) {
+	end := p.skip + p.limit
+	if end > int64(len(p.data)) {
+		end = int64(len(p.data))
+	}
+	if p.skip >= end {
+		return 0, nil
+	}
+	n, err := w.Write(p.data[p.skip:end])
+	return int64(n), err
+}
+
+// serveBytes writes data to w, honoring a Range request header with
+// support for multiple ranges (served as multipart/byteranges per
+// RFC 7233). encoding, if non-empty, is sent as Content-Encoding on both
+// full and partial responses; contentType describes a single range's body.
+func serveBytes(w http.ResponseWriter, r *http.Request, data []byte, contentType, encoding string) {
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+	}
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	rangeHeader := r.Header.Get("Range")
+	if rangeHeader == "" {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(data)
+		return
+	}
+
+	size := int64(len(data))
+	ranges, err := parseRange(rangeHeader, size)
+	if err != nil {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		http.Error(w, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	if len(ranges) == 0 {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(data)
+		return
+	}
+
+	if len(ranges) == 1 {
+		ra := ranges[0]
+		w.Header().Set("Content-Range", ra.contentRange(size))
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusPartialContent)
+		newPartialRangeWriter(data, ra).WriteTo(w)
+		return
+	}
+
+	mw := multipart.NewWriter(w)
+	w.Header().Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	w.WriteHeader(http.StatusPartialContent)
+	for _, ra := range ranges {
+		part, err := mw.CreatePart(ra.mimeHeader(contentType, size))
+		if err != nil {
+			return
+		}
+		newPartialRangeWriter(data, ra).WriteTo(part)
+	}
+	mw.Close()
+}
+
+// acceptedEncoding parses an Accept-Encoding header (RFC 7231 §5.3.4) and
+// returns the highest-q encoding in available (given in preference order)
+// that the client accepts, or "" if none match (the caller should fall
+// back to an identity response).
+func acceptedEncoding(header string, available []string) string {
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if _, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = parsed
+			}
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, name := range available {
+		q, explicit := weights[name]
+		if !explicit {
+			wildcardQ, ok := weights["*"]
+			if !ok {
+				continue
+			}
+			q = wildcardQ
+		}
+		if q > 0 && q > bestQ {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// negotiateAndServe picks the best encoding the client accepts among
+// variants (honoring the cache's configured preference order) and serves
+// it, falling back to the uncompressed raw bytes if none match.
+func negotiateAndServe(w http.ResponseWriter, r *http.Request, cache *IconCache, raw []byte, variants compressedVariants, contentType string) {
+	encoding := acceptedEncoding(r.Header.Get("Accept-Encoding"), cache.encodingPreference())
+	if data, ok := variants.get(encoding); ok {
+		serveBytes(w, r, data, contentType, encoding)
+		return
+	}
+	serveBytes(w, r, raw, contentType, "")
+}
+
+// listingQueryActive reports whether query asks for anything other than
+// the default (unsorted, unpaginated) listing page.
+func listingQueryActive(query url.Values) bool {
+	return query.Has("sort") || query.Has("order") || query.Has("limit") || query.Has("page")
+}
+
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// parseListingQuery reads the sort/order/page/limit query parameters,
+// applying the same defaults as the default cached listing page.
+func parseListingQuery(query url.Values) (sortKey, order string, page, limit int) {
+	sortKey = strings.ToLower(query.Get("sort"))
+	if sortKey != "size" && sortKey != "modtime" {
+		sortKey = "name"
+	}
+	order = strings.ToLower(query.Get("order"))
+	if order != "desc" {
+		order = "asc"
+	}
+	page = 1
+	if v, err := strconv.Atoi(query.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	return sortKey, order, page, limit
+}
+
 func iconHandler(cfg *Config, cache *IconCache) http.Handler {
 	return RequestLogger(cfg, CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Handle requests for the root path "/Icons/" or "/Icons"
 		if r.URL.Path == "/Icons/" || r.URL.Path == "/Icons" {
-			if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
-				w.Header().Set("Content-Encoding", "gzip")
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.Write(cache.GetGzippedHTML())
-			} else {
-				w.Header().Set("Content-Type", "text/html; charset=utf-8")
-				w.Write(cache.GetHTML())
+			query := r.URL.Query()
+
+			// A bare, un-parameterized, non-JSON request gets the fast path:
+			// the pre-rendered, pre-compressed page with full conditional
+			// request and range support.
+			if !listingQueryActive(query) && !wantsJSON(r) {
+				etag, modTime := cache.GetHTMLMeta()
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+				w.Header().Set("Cache-Control", cacheControlHeader)
+				w.Header().Set("Vary", "Accept-Encoding")
+
+				if status := checkPreconditions(r, etag, modTime); status != 0 {
+					w.WriteHeader(status)
+					return
+				}
+
+				negotiateAndServe(w, r, cache, cache.GetHTML(), cache.GetHTMLEncoded(), "text/html; charset=utf-8")
+				return
 			}
+
+			sortKey, order, page, limit := parseListingQuery(query)
+			entries := cache.ListingEntries(sortKey, order)
+			total := len(entries)
+			paged := paginateListing(entries, page, limit)
+
+			if wantsJSON(r) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Vary", "Accept, Accept-Encoding")
+				json.NewEncoder(w).Encode(ListingPage{
+					Icons: paged,
+					Total: total,
+					Page:  page,
+					Limit: limit,
+					Sort:  sortKey,
+					Order: order,
+				})
+				return
+			}
+
+			body, err := cache.RenderListing(paged, total, page, limit, sortKey, order)
+			if err != nil {
+				http.Error(w, "failed to render listing", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Vary", "Accept, Accept-Encoding")
+			w.Write(body)
 			return
 		}
 
 		// Handle requests for specific icons
 		iconName := strings.TrimPrefix(r.URL.Path, "/Icons/")
-		if content, exists := cache.GetIcon(iconName); exists {
-			w.Header().Set("Content-Type", "image/svg+xml")
-			w.Write(content)
-		} else {
+		entry, exists := cache.GetIcon(iconName)
+		if !exists {
 			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("ETag", entry.etag)
+		w.Header().Set("Last-Modified", entry.modTime.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", cacheControlHeader)
+		w.Header().Set("Vary", "Accept-Encoding")
+
+		if status := checkPreconditions(r, entry.etag, entry.modTime); status != 0 {
+			w.WriteHeader(status)
+			return
 		}
+
+		negotiateAndServe(w, r, cache, entry.content, entry.encoded, "image/svg+xml")
 	})))
 }
 
@@ -647,6 +1836,203 @@ func listHandler(cfg *Config, cache *IconCache) http.Handler {
 	})))
 }
 
+func parseThreshold(query url.Values) int {
+	threshold := defaultDuplicateThreshold
+	if v := query.Get("threshold"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			threshold = n
+		}
+	}
+	return threshold
+}
+
+func duplicatesHandler(cfg *Config, cache *IconCache) http.Handler {
+	return RequestLogger(cfg, CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		threshold := parseThreshold(r.URL.Query())
+		groups := cache.FindDuplicateGroups(threshold)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Threshold int        `json:"threshold"`
+			Groups    [][]string `json:"groups"`
+		}{threshold, groups})
+	})))
+}
+
+func similarHandler(cfg *Config, cache *IconCache) http.Handler {
+	return RequestLogger(cfg, CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		name := query.Get("name")
+		if name == "" {
+			http.Error(w, `missing required "name" query parameter`, http.StatusBadRequest)
+			return
+		}
+
+		threshold := parseThreshold(query)
+		matches, exists := cache.SimilarTo(name, threshold)
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Name      string   `json:"name"`
+			Threshold int      `json:"threshold"`
+			Matches   []string `json:"matches"`
+		}{name, threshold, matches})
+	})))
+}
+
+// manifestHandler serves /Icons/manifest.json, a machine-readable listing of
+// every icon with its size, SHA-256, and the cache's current revision,
+// optionally signed with Config.SigningKeyPath.
+func manifestHandler(cfg *Config, cache *IconCache) http.Handler {
+	return RequestLogger(cfg, CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		manifest, err := cache.Manifest()
+		if err != nil {
+			http.Error(w, "failed to build manifest", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(manifest)
+	})))
+}
+
+// byHashHandler serves /Icons/by-hash/{sha256}, letting downstream apps pin
+// exact icon bytes by content hash even if the file is later renamed.
+func byHashHandler(cfg *Config, cache *IconCache) http.Handler {
+	return RequestLogger(cfg, CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hash := strings.ToLower(strings.TrimPrefix(r.URL.Path, "/Icons/by-hash/"))
+		entry, exists := cache.GetByHash(hash)
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("ETag", entry.etag)
+		w.Header().Set("Last-Modified", entry.modTime.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", cacheControlHeader)
+		w.Header().Set("Vary", "Accept-Encoding")
+
+		if status := checkPreconditions(r, entry.etag, entry.modTime); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+
+		negotiateAndServe(w, r, cache, entry.content, entry.encoded, "image/svg+xml")
+	})))
+}
+
+// bundleHandler serves /Icons/bundle.{zip,tar,tar.zst}, streaming an archive
+// of every icon in the cache (optionally narrowed by ?prefix= and ?search=)
+// built on the fly with no temp files. The archive's ETag is derived from
+// the manifest revision and the requested filter, so conditional GET works
+// without rebuilding the archive.
+func bundleHandler(cfg *Config, cache *IconCache, format string) http.Handler {
+	return RequestLogger(cfg, CORSMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		prefix := query.Get("prefix")
+		search := query.Get("search")
+
+		icons, lastUpdated, revision := cache.BundleIcons(prefix, search)
+
+		etag := computeETag([]byte(fmt.Sprintf("bundle:%s:%d:%s:%s", format, revision, prefix, search)))
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastUpdated.UTC().Format(http.TimeFormat))
+		w.Header().Set("Cache-Control", cacheControlHeader)
+
+		if status := checkPreconditions(r, etag, lastUpdated); status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+
+		switch format {
+		case "zip":
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", `attachment; filename="icons.zip"`)
+			if err := writeZipBundle(w, icons, lastUpdated); err != nil {
+				log.Printf("%sFailed to write zip bundle: %v%s", colorRed, err, colorReset)
+			}
+		case "tar":
+			w.Header().Set("Content-Type", "application/x-tar")
+			w.Header().Set("Content-Disposition", `attachment; filename="icons.tar"`)
+			if err := writeTarBundle(w, icons, lastUpdated); err != nil {
+				log.Printf("%sFailed to write tar bundle: %v%s", colorRed, err, colorReset)
+			}
+		case "tar.zst":
+			w.Header().Set("Content-Type", "application/zstd")
+			w.Header().Set("Content-Disposition", `attachment; filename="icons.tar.zst"`)
+			zw, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstdLevel(cfg.Compression.Level)))
+			if err != nil {
+				log.Printf("%sFailed to create zstd writer: %v%s", colorRed, err, colorReset)
+				return
+			}
+			defer zw.Close()
+			if err := writeTarBundle(zw, icons, lastUpdated); err != nil {
+				log.Printf("%sFailed to write tar.zst bundle: %v%s", colorRed, err, colorReset)
+			}
+		}
+	})))
+}
+
+// writeZipBundle writes icons to w as a zip archive, with every entry's
+// modified time set to modTime.
+func writeZipBundle(w io.Writer, icons []bundleIcon, modTime time.Time) error {
+	zw := zip.NewWriter(w)
+	for _, icon := range icons {
+		header := &zip.FileHeader{Name: icon.name, Method: zip.Deflate}
+		header.Modified = modTime
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(icon.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeTarBundle writes icons to w as a tar archive, with every entry's
+// mtime set to modTime.
+func writeTarBundle(w io.Writer, icons []bundleIcon, modTime time.Time) error {
+	tw := tar.NewWriter(w)
+	for _, icon := range icons {
+		header := &tar.Header{
+			Name:    icon.name,
+			Size:    int64(len(icon.content)),
+			Mode:    0644,
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(icon.content); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// redirectToHTTPSHandler permanently redirects plain-HTTP requests to the
+// HTTPS equivalent on httpsPort, for the :80 listener that runs alongside a
+// TLS-enabled server.
+func redirectToHTTPSHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
+
 func watchDirectory(dir string, cache *IconCache) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -750,7 +2136,7 @@ func main() {
 
 	log.Printf("%sLoading icons...%s", colorCyan, colorReset)
 	start := time.Now()
-	cache, err := NewIconCache(cfg.IconDir)
+	cache, err := NewIconCache(cfg)
 	elapsed := time.Since(start)
 	if err != nil {
 		log.Fatalf("%sError initializing icon cache: %v%s", colorRed, err, colorReset)
@@ -763,10 +2149,56 @@ func main() {
 
 	http.Handle("/Icons/", iconHandler(cfg, cache))
 	http.Handle("/Icons/list", listHandler(cfg, cache))
+	http.Handle("/Icons/duplicates", duplicatesHandler(cfg, cache))
+	http.Handle("/Icons/similar", similarHandler(cfg, cache))
+	http.Handle("/Icons/manifest.json", manifestHandler(cfg, cache))
+	http.Handle("/Icons/by-hash/", byHashHandler(cfg, cache))
+	http.Handle("/Icons/bundle.zip", bundleHandler(cfg, cache, "zip"))
+	http.Handle("/Icons/bundle.tar", bundleHandler(cfg, cache, "tar"))
+	http.Handle("/Icons/bundle.tar.zst", bundleHandler(cfg, cache, "tar.zst"))
+
+	switch cfg.TLS.Mode {
+	case "file":
+		go func() {
+			log.Printf("%sRedirecting http://:80 to https%s", colorGray, colorReset)
+			if err := http.ListenAndServe(":80", redirectToHTTPSHandler(cfg.Port)); err != nil {
+				log.Printf("%sHTTP redirect listener failed: %v%s", colorRed, err, colorReset)
+			}
+		}()
+
+		log.Printf("%sServing icons on https://localhost:%s/Icons/%s", colorCyan, cfg.Port, colorReset)
+		log.Printf("%sServer starting on port %s (TLS, file)...%s", colorGreen, cfg.Port, colorReset)
+		server := &http.Server{Addr: ":" + cfg.Port}
+		if err := server.ListenAndServeTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile); err != nil {
+			log.Fatalf("%sServer failed to start: %v%s", colorRed, err, colorReset)
+		}
 
-	log.Printf("%sServing icons on http://localhost:%s/Icons/%s\n", colorCyan, cfg.Port, colorReset)
-	log.Printf("%sServer starting on port %s...%s", colorGreen, cfg.Port, colorReset)
-	if err := http.ListenAndServe(":"+cfg.Port, nil); err != nil {
-		log.Fatalf("%sServer failed to start: %v%s", colorRed, err, colorReset)
+	case "acme":
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLS.HostWhitelist...),
+			Cache:      autocert.DirCache(cfg.TLS.CacheDir),
+		}
+
+		go func() {
+			log.Printf("%sServing ACME HTTP-01 challenges (and redirects) on :80%s", colorGray, colorReset)
+			if err := http.ListenAndServe(":80", manager.HTTPHandler(redirectToHTTPSHandler(cfg.Port))); err != nil {
+				log.Printf("%sACME HTTP listener failed: %v%s", colorRed, err, colorReset)
+			}
+		}()
+
+		log.Printf("%sServing icons on https://localhost:%s/Icons/ (ACME)%s", colorCyan, cfg.Port, colorReset)
+		log.Printf("%sServer starting on port %s (TLS, ACME)...%s", colorGreen, cfg.Port, colorReset)
+		server := &http.Server{Addr: ":" + cfg.Port, TLSConfig: manager.TLSConfig()}
+		if err := server.ListenAndServeTLS("", ""); err != nil {
+			log.Fatalf("%sServer failed to start: %v%s", colorRed, err, colorReset)
+		}
+
+	default:
+		log.Printf("%sServing icons on http://localhost:%s/Icons/%s\n", colorCyan, cfg.Port, colorReset)
+		log.Printf("%sServer starting on port %s...%s", colorGreen, cfg.Port, colorReset)
+		if err := http.ListenAndServe(":"+cfg.Port, nil); err != nil {
+			log.Fatalf("%sServer failed to start: %v%s", colorRed, err, colorReset)
+		}
 	}
 }